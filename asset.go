@@ -0,0 +1,51 @@
+package gtml
+
+import "net/url"
+
+// AssetResolver maps a logical asset path such as "css/app.css" to the path
+// templates should emit, typically a fingerprinted filename or a path with a
+// version query string. The built-in asset func returns paths unchanged;
+// supply a resolver under the same name to MergeFuncMaps to replace it:
+//
+//	funcs := gtml.MergeFuncMaps(template.FuncMap{
+//		"asset": gtml.ManifestResolver(manifest),
+//	})
+type AssetResolver func(path string) string
+
+// ManifestResolver returns an AssetResolver backed by manifest, such as one
+// decoded from a bundler's manifest.json mapping "css/app.css" to
+// "css/app.a1b2c3.css". Paths missing from the manifest are returned
+// unchanged.
+func ManifestResolver(manifest map[string]string) AssetResolver {
+	return func(path string) string {
+		if resolved, ok := manifest[path]; ok {
+			return resolved
+		}
+		return path
+	}
+}
+
+// VersionResolver returns an AssetResolver that appends a "v" query
+// parameter set to version, turning "css/app.css" into
+// "css/app.css?v=a1b2c3". Any query already on the path is kept as written.
+func VersionResolver(version string) AssetResolver {
+	param := "v=" + url.QueryEscape(version)
+	return func(path string) string {
+		u, err := url.Parse(path)
+		if err != nil {
+			return path
+		}
+
+		if u.RawQuery == "" {
+			u.RawQuery = param
+		} else {
+			u.RawQuery += "&" + param
+		}
+		return u.String()
+	}
+}
+
+// assetPath is the default asset func, which returns path unchanged.
+func assetPath(path string) string {
+	return path
+}
@@ -0,0 +1,34 @@
+package gtml
+
+import (
+	"html/template"
+	"testing"
+)
+
+func TestAssetFunc(t *testing.T) {
+	manifest := map[string]string{"css/app.css": "css/app.a1b2c3.css"}
+
+	tests := []struct {
+		name  string
+		funcs template.FuncMap
+		path  string
+		want  string
+	}{
+		{name: "default", funcs: nil, path: "css/app.css", want: "css/app.css"},
+		{name: "manifest", funcs: template.FuncMap{"asset": ManifestResolver(manifest)}, path: "css/app.css", want: "css/app.a1b2c3.css"},
+		{name: "manifest miss", funcs: template.FuncMap{"asset": ManifestResolver(manifest)}, path: "js/app.js", want: "js/app.js"},
+		{name: "version", funcs: template.FuncMap{"asset": VersionResolver("a1b2c3")}, path: "css/app.css", want: "css/app.css?v=a1b2c3"},
+		{name: "version keeps query", funcs: template.FuncMap{"asset": VersionResolver("a1b2c3")}, path: "img/logo.svg?w=40&h=20", want: "img/logo.svg?w=40&amp;h=20&amp;v=a1b2c3"},
+		{name: "version keeps fragment", funcs: template.FuncMap{"asset": VersionResolver("a1b2c3")}, path: "icons.svg#close", want: "icons.svg?v=a1b2c3#close"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := execute(t, tt.funcs, `<link href="{{asset .}}">`, tt.path)
+			want := `<link href="` + tt.want + `">`
+			if got != want {
+				t.Errorf("got %q, want %q", got, want)
+			}
+		})
+	}
+}
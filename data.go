@@ -0,0 +1,139 @@
+package gtml
+
+import (
+	"reflect"
+	"strings"
+)
+
+// StructToData converts a struct (or pointer to struct) into a view data map
+// so typed models can be merged with other map-based data.
+//
+// Keys are taken from the `gtml` struct tag, falling back to the `json` tag
+// and then the field name. A tag name of "-" skips the field, and the
+// "omitempty" option skips zero values. Unexported fields are ignored and
+// fields of embedded structs are promoted unless an outer field uses the same
+// key. A map[string]any is returned as a shallow copy; nil or any other type
+// yields nil.
+func StructToData(v any) map[string]any {
+	if m, ok := v.(map[string]any); ok {
+		data := make(map[string]any, len(m))
+		for k, val := range m {
+			data[k] = val
+		}
+		return data
+	}
+
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil
+	}
+
+	data := make(map[string]any, rv.NumField())
+	addStructFields(data, rv, map[reflect.Type]bool{})
+	return data
+}
+
+// addStructFields copies the exported fields of rv into data. Embedded
+// structs are visited after the direct fields so outer keys take precedence.
+// Like encoding/json, an embedded struct whose type is already being visited
+// is skipped, so self-referential embedding cannot recurse forever.
+func addStructFields(data map[string]any, rv reflect.Value, visiting map[reflect.Type]bool) {
+	rt := rv.Type()
+	visiting[rt] = true
+	defer delete(visiting, rt)
+
+	var embedded []reflect.Value
+
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		name, omitEmpty, skip := fieldKey(field)
+		if skip {
+			continue
+		}
+
+		fv := rv.Field(i)
+		if field.Anonymous && name == "" {
+			if inner, ok := embeddedStruct(fv); ok {
+				if !visiting[inner.Type()] {
+					embedded = append(embedded, inner)
+				}
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if omitEmpty && isEmptyValue(fv) {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		data[name] = fv.Interface()
+	}
+
+	for _, inner := range embedded {
+		promoted := make(map[string]any, inner.NumField())
+		addStructFields(promoted, inner, visiting)
+		for k, val := range promoted {
+			if _, exists := data[k]; !exists {
+				data[k] = val
+			}
+		}
+	}
+}
+
+// fieldKey reports the tagged name for field, whether it carries the
+// omitempty option, and whether it should be skipped entirely.
+func fieldKey(field reflect.StructField) (name string, omitEmpty, skip bool) {
+	tag, ok := field.Tag.Lookup("gtml")
+	if !ok {
+		tag = field.Tag.Get("json")
+	}
+	if tag == "-" {
+		return "", false, true
+	}
+
+	name, opts, _ := strings.Cut(tag, ",")
+	for _, opt := range strings.Split(opts, ",") {
+		if opt == "omitempty" {
+			omitEmpty = true
+		}
+	}
+	return name, omitEmpty, false
+}
+
+// isEmptyValue reports whether v is empty under the omitempty rules of
+// encoding/json: false, 0, a nil pointer or interface, and any array, map,
+// slice or string of length zero. Structs are never empty.
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64,
+		reflect.Interface, reflect.Pointer:
+		return v.IsZero()
+	}
+	return false
+}
+
+// embeddedStruct dereferences an embedded field and reports whether it holds
+// a struct whose fields should be promoted.
+func embeddedStruct(fv reflect.Value) (reflect.Value, bool) {
+	if fv.Kind() == reflect.Pointer {
+		if fv.IsNil() {
+			return reflect.Value{}, false
+		}
+		fv = fv.Elem()
+	}
+	return fv, fv.Kind() == reflect.Struct
+}
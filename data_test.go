@@ -0,0 +1,170 @@
+package gtml
+
+import (
+	"reflect"
+	"testing"
+)
+
+type dataBase struct {
+	ID   int
+	Name string
+}
+
+type dataAudit struct {
+	Created string `json:"created"`
+}
+
+type dataModel struct {
+	dataBase
+	*dataAudit
+	Name     string `gtml:"Title" json:"name"`
+	Email    string `json:"email"`
+	Internal string `gtml:"-"`
+	Hidden   string `json:"-"`
+	Note     string `json:"note,omitempty"`
+	Count    int
+	secret   string
+}
+
+func TestStructToData(t *testing.T) {
+	tests := []struct {
+		name string
+		in   any
+		want map[string]any
+	}{
+		{
+			name: "tags and promotion",
+			in: dataModel{
+				dataBase:  dataBase{ID: 7, Name: "inner"},
+				dataAudit: &dataAudit{Created: "today"},
+				Name:      "outer",
+				Email:     "a@example.com",
+				Internal:  "x",
+				Hidden:    "y",
+				Count:     3,
+				secret:    "z",
+			},
+			want: map[string]any{
+				"ID":      7,
+				"Name":    "inner",
+				"Title":   "outer",
+				"email":   "a@example.com",
+				"Count":   3,
+				"created": "today",
+			},
+		},
+		{
+			name: "omitempty keeps non-zero values",
+			in:   &dataModel{Note: "hi"},
+			want: map[string]any{
+				"ID":    0,
+				"Name":  "",
+				"Title": "",
+				"email": "",
+				"note":  "hi",
+				"Count": 0,
+			},
+		},
+		{
+			name: "outer field wins over promoted field",
+			in: struct {
+				dataBase
+				ID string
+			}{dataBase: dataBase{ID: 1}, ID: "outer"},
+			want: map[string]any{"ID": "outer", "Name": ""},
+		},
+		{
+			name: "map is copied",
+			in:   map[string]any{"a": 1},
+			want: map[string]any{"a": 1},
+		},
+		{name: "nil", in: nil, want: nil},
+		{name: "nil pointer", in: (*dataModel)(nil), want: nil},
+		{name: "not a struct", in: 42, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := StructToData(tt.in)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("StructToData() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStructToDataCopiesMap(t *testing.T) {
+	in := map[string]any{"a": 1}
+	got := StructToData(in)
+	got["b"] = 2
+
+	if _, ok := in["b"]; ok {
+		t.Error("StructToData modified the input map")
+	}
+}
+
+type dataNode struct {
+	*dataNode
+	X int
+}
+
+type dataLeft struct {
+	*dataRight
+	L int
+}
+
+type dataRight struct {
+	*dataLeft
+	R int
+}
+
+func TestStructToDataEmbeddedCycle(t *testing.T) {
+	node := &dataNode{X: 1}
+	node.dataNode = node
+
+	left := &dataLeft{L: 1}
+	left.dataRight = &dataRight{dataLeft: left, R: 2}
+
+	tests := []struct {
+		name string
+		in   any
+		want map[string]any
+	}{
+		{name: "self-referential", in: node, want: map[string]any{"X": 1}},
+		{name: "mutually embedded", in: left, want: map[string]any{"L": 1, "R": 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := StructToData(tt.in)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("StructToData() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStructToDataOmitEmpty(t *testing.T) {
+	type when struct{ Day int }
+	type model struct {
+		Tags   []string       `json:"tags,omitempty"`
+		Meta   map[string]int `json:"meta,omitempty"`
+		Ptr    *int           `json:"ptr,omitempty"`
+		Flag   bool           `json:"flag,omitempty"`
+		When   when           `json:"when,omitempty"`
+		Filled []string       `json:"filled,omitempty"`
+	}
+
+	got := StructToData(model{
+		Tags:   []string{},
+		Meta:   map[string]int{},
+		Filled: []string{"a"},
+	})
+	want := map[string]any{
+		"when":   when{},
+		"filled": []string{"a"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("StructToData() = %#v, want %#v", got, want)
+	}
+}
@@ -0,0 +1,2 @@
+// Package gtml provides helpers for rendering HTML with Go's html/template.
+package gtml
@@ -0,0 +1,158 @@
+package gtml
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"reflect"
+)
+
+// baseFuncMap holds the built-in helpers documented on MergeFuncMaps.
+var baseFuncMap = template.FuncMap{
+	"dict":       dict,
+	"default":    defaultValue,
+	"ternary":    ternary,
+	"safeHTML":   safeHTML,
+	"safeURL":    safeURL,
+	"truncate":   truncate,
+	"pluralize":  pluralize,
+	"jsonScript": jsonScript,
+	"paginate":   NewPaginator,
+	"asset":      assetPath,
+}
+
+// MergeFuncMaps returns a new FuncMap containing the built-in helpers
+// overlaid with funcMaps in order. A func in a later map replaces one of the
+// same name from an earlier map or from the built-in set, so callers can
+// always override a built-in helper.
+//
+// The built-in helpers are:
+//
+//	dict "k1" v1 "k2" v2     build a map, e.g. to pass several values to a partial
+//	default fallback value   value, or fallback when value is empty
+//	ternary a b cond         a when cond is true, otherwise b
+//	safeHTML s               mark s as trusted HTML
+//	safeURL s                mark s as a trusted URL
+//	truncate n s             s cut to n runes with an ellipsis appended
+//	pluralize one many n     one when n == 1, otherwise many
+//	jsonScript v             v as JSON safe to embed in a <script> element
+//	paginate page per total  a *Paginator for building pagination controls
+//	asset path               path unchanged; replace it with an AssetResolver
+//
+// Helpers that transform a value take it as their last argument so they can
+// be used in pipelines, e.g. {{.Title | default "Untitled"}}. When calling
+// them directly, pass the value last as well: {{default "Untitled" .Title}},
+// not {{default .Title "Untitled"}}.
+func MergeFuncMaps(funcMaps ...template.FuncMap) template.FuncMap {
+	merged := make(template.FuncMap, len(baseFuncMap))
+	for name, fn := range baseFuncMap {
+		merged[name] = fn
+	}
+	for _, fm := range funcMaps {
+		for name, fn := range fm {
+			merged[name] = fn
+		}
+	}
+	return merged
+}
+
+// jsonScript marshals v to JSON that is safe to place inside a <script>
+// element, e.g. <script>window.__DATA__ = {{jsonScript .Payload}}</script>.
+// The characters <, > and & are escaped so the payload cannot close the
+// script element, and U+2028 and U+2029 are escaped because older JavaScript
+// engines treat them as line terminators inside string literals.
+func jsonScript(v any) (template.JS, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(true)
+	if err := enc.Encode(v); err != nil {
+		return "", err
+	}
+	return template.JS(bytes.TrimRight(buf.Bytes(), "\n")), nil
+}
+
+// dict builds a map from alternating keys and values so several values can be
+// passed to a partial: {{template "partials/button" (dict "Label" "Save")}}.
+func dict(pairs ...any) (map[string]any, error) {
+	if len(pairs)%2 != 0 {
+		return nil, errors.New("dict: odd number of arguments")
+	}
+
+	m := make(map[string]any, len(pairs)/2)
+	for i := 0; i < len(pairs); i += 2 {
+		key, ok := pairs[i].(string)
+		if !ok {
+			return nil, fmt.Errorf("dict: key %v is %T, not string", pairs[i], pairs[i])
+		}
+		m[key] = pairs[i+1]
+	}
+	return m, nil
+}
+
+// defaultValue returns value unless it is empty, in which case it returns
+// fallback. Nil, zero values and empty strings, slices and maps are empty.
+func defaultValue(fallback, value any) any {
+	if isEmpty(value) {
+		return fallback
+	}
+	return value
+}
+
+// isEmpty reports whether v is nil, a zero value, or an empty collection.
+func isEmpty(v any) bool {
+	if v == nil {
+		return true
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Array, reflect.Chan, reflect.Map, reflect.Slice, reflect.String:
+		return rv.Len() == 0
+	default:
+		return rv.IsZero()
+	}
+}
+
+// ternary returns whenTrue if cond is true and whenFalse otherwise.
+func ternary(whenTrue, whenFalse any, cond bool) any {
+	if cond {
+		return whenTrue
+	}
+	return whenFalse
+}
+
+// safeHTML marks s as trusted HTML so it is not escaped. Only use it with
+// content that is known to be safe.
+func safeHTML(s string) template.HTML {
+	return template.HTML(s)
+}
+
+// safeURL marks s as a trusted URL so it is not sanitized. Only use it with
+// URLs that are known to be safe.
+func safeURL(s string) template.URL {
+	return template.URL(s)
+}
+
+// truncate keeps the first length runes of s, appending an ellipsis when
+// anything was removed.
+func truncate(length int, s string) string {
+	if length < 0 {
+		length = 0
+	}
+
+	runes := []rune(s)
+	if len(runes) <= length {
+		return s
+	}
+	return string(runes[:length]) + "…"
+}
+
+// pluralize returns singular when count is 1 and plural otherwise.
+func pluralize(singular, plural string, count int) string {
+	if count == 1 {
+		return singular
+	}
+	return plural
+}
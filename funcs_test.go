@@ -0,0 +1,240 @@
+package gtml
+
+import (
+	"bytes"
+	"html/template"
+	"strings"
+	"testing"
+)
+
+// execute parses text with the merged func map and renders it with data.
+func execute(t *testing.T, funcs template.FuncMap, text string, data any) string {
+	t.Helper()
+
+	tmpl, err := template.New("test").Funcs(MergeFuncMaps(funcs)).Parse(text)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	return buf.String()
+}
+
+func TestMergeFuncMapsUserWins(t *testing.T) {
+	custom := func(any) string { return "custom" }
+	merged := MergeFuncMaps(template.FuncMap{"jsonScript": custom})
+
+	got := execute(t, merged, `{{jsonScript .}}`, 1)
+	if got != "custom" {
+		t.Errorf("got %q, want the user-supplied func to win", got)
+	}
+	if _, ok := baseFuncMap["jsonScript"].(func(any) (template.JS, error)); !ok {
+		t.Error("MergeFuncMaps modified the built-in func map")
+	}
+}
+
+func TestMergeFuncMapsLaterMapWins(t *testing.T) {
+	merged := MergeFuncMaps(
+		template.FuncMap{"greet": func() string { return "first" }},
+		template.FuncMap{"greet": func() string { return "second" }},
+	)
+
+	if got := execute(t, merged, `{{greet}}`, nil); got != "second" {
+		t.Errorf("got %q, want %q", got, "second")
+	}
+}
+
+func TestJSONScript(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload any
+		want    string
+	}{
+		{
+			name:    "closing script tag",
+			payload: map[string]string{"html": "</script><script>alert(1)</script>"},
+			want:    `{"html":"\u003c/script\u003e\u003cscript\u003ealert(1)\u003c/script\u003e"}`,
+		},
+		{
+			name:    "ampersand",
+			payload: "a & b",
+			want:    `"a \u0026 b"`,
+		},
+		{
+			name:    "line and paragraph separators",
+			payload: "line\u2028para\u2029end",
+			want:    `"line\u2028para\u2029end"`,
+		},
+		{
+			name:    "plain values",
+			payload: []int{1, 2},
+			want:    `[1,2]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := execute(t, nil, `<script>window.__DATA__ = {{jsonScript .}}</script>`, tt.payload)
+			want := "<script>window.__DATA__ = " + tt.want + "</script>"
+			if got != want {
+				t.Errorf("got %s\nwant %s", got, want)
+			}
+			if strings.Count(got, "</script>") != 1 {
+				t.Errorf("payload closed the script element early: %s", got)
+			}
+		})
+	}
+}
+
+func TestJSONScriptMarshalError(t *testing.T) {
+	tmpl := template.Must(template.New("test").Funcs(MergeFuncMaps()).Parse(`<script>{{jsonScript .}}</script>`))
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, make(chan int)); err == nil {
+		t.Error("expected an error for a value that cannot be marshalled")
+	}
+}
+
+func TestDict(t *testing.T) {
+	text := `{{define "button"}}<button class="{{.Variant}}">{{.Label}}</button>{{end}}` +
+		`{{template "button" (dict "Label" "Save" "Variant" "primary")}}`
+
+	got := execute(t, nil, text, nil)
+	want := `<button class="primary">Save</button>`
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestDictErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		pairs []any
+	}{
+		{name: "odd arguments", pairs: []any{"a", 1, "b"}},
+		{name: "non-string key", pairs: []any{1, "a"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := dict(tt.pairs...); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestDefault(t *testing.T) {
+	tests := []struct {
+		name string
+		data any
+		want string
+	}{
+		{name: "nil", data: nil, want: "fallback"},
+		{name: "empty string", data: "", want: "fallback"},
+		{name: "zero int", data: 0, want: "fallback"},
+		{name: "empty slice", data: []string{}, want: "fallback"},
+		{name: "empty map", data: map[string]any{}, want: "fallback"},
+		{name: "string", data: "value", want: "value"},
+		{name: "int", data: 3, want: "3"},
+		{name: "false", data: false, want: "fallback"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := execute(t, nil, `{{. | default "fallback"}}`, tt.data); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDefaultDirectCall(t *testing.T) {
+	tests := []struct {
+		name  string
+		title string
+		want  string
+	}{
+		{name: "value set", title: "Real", want: "Real"},
+		{name: "value empty", title: "", want: "Untitled"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := execute(t, nil, `{{default "Untitled" .Title}}`, map[string]string{"Title": tt.title})
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTernary(t *testing.T) {
+	if got := execute(t, nil, `{{ternary "yes" "no" .}}`, true); got != "yes" {
+		t.Errorf("true: got %q, want %q", got, "yes")
+	}
+	if got := execute(t, nil, `{{. | ternary "yes" "no"}}`, false); got != "no" {
+		t.Errorf("false: got %q, want %q", got, "no")
+	}
+}
+
+func TestSafeHTML(t *testing.T) {
+	got := execute(t, nil, `{{safeHTML .}}|{{.}}`, "<b>bold</b>")
+	want := `<b>bold</b>|&lt;b&gt;bold&lt;/b&gt;`
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestSafeURL(t *testing.T) {
+	got := execute(t, nil, `<a href="{{safeURL .}}"></a><a href="{{.}}"></a>`, "tel:+15551234")
+	want := `<a href="tel:&#43;15551234"></a><a href="#ZgotmplZ"></a>`
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		name   string
+		length int
+		in     string
+		want   string
+	}{
+		{name: "shorter", length: 10, in: "hello", want: "hello"},
+		{name: "exact", length: 5, in: "hello", want: "hello"},
+		{name: "longer", length: 3, in: "hello", want: "hel…"},
+		{name: "multibyte", length: 2, in: "héllo", want: "hé…"},
+		{name: "zero", length: 0, in: "hello", want: "…"},
+		{name: "negative", length: -1, in: "hello", want: "…"},
+		{name: "empty", length: 3, in: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := truncate(tt.length, tt.in); got != tt.want {
+				t.Errorf("truncate(%d, %q) = %q, want %q", tt.length, tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPluralize(t *testing.T) {
+	tests := []struct {
+		count int
+		want  string
+	}{
+		{count: 0, want: "0 items"},
+		{count: 1, want: "1 item"},
+		{count: 2, want: "2 items"},
+	}
+
+	for _, tt := range tests {
+		got := execute(t, nil, `{{.}} {{. | pluralize "item" "items"}}`, tt.count)
+		if got != tt.want {
+			t.Errorf("count %d: got %q, want %q", tt.count, got, tt.want)
+		}
+	}
+}
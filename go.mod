@@ -0,0 +1,3 @@
+module github.com/patrickward/gtml
+
+go 1.22
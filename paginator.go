@@ -0,0 +1,113 @@
+package gtml
+
+import (
+	"net/url"
+	"strconv"
+)
+
+// Paginator describes one page of a paged result set for rendering
+// pagination controls. Pages are numbered from 1.
+type Paginator struct {
+	CurrentPage int
+	TotalPages  int
+	PerPage     int
+	TotalItems  int
+}
+
+// NewPaginator returns a Paginator for totalItems split into pages of
+// perPage items. The current page is clamped to the available pages, and a
+// result set with no items still has a single, empty page. It is registered
+// as the paginate template func: {{$p := paginate .Page 20 .Total}}.
+func NewPaginator(currentPage, perPage, totalItems int) *Paginator {
+	if perPage < 1 {
+		perPage = 1
+	}
+	if totalItems < 0 {
+		totalItems = 0
+	}
+
+	totalPages := (totalItems + perPage - 1) / perPage
+	if totalPages < 1 {
+		totalPages = 1
+	}
+
+	return &Paginator{
+		CurrentPage: min(max(currentPage, 1), totalPages),
+		TotalPages:  totalPages,
+		PerPage:     perPage,
+		TotalItems:  totalItems,
+	}
+}
+
+// Pages returns every page number from 1 to TotalPages.
+func (p Paginator) Pages() []int {
+	pages := make([]int, 0, p.TotalPages)
+	for i := 1; i <= p.TotalPages; i++ {
+		pages = append(pages, i)
+	}
+	return pages
+}
+
+// HasPrev reports whether there is a page before the current one.
+func (p Paginator) HasPrev() bool {
+	return p.CurrentPage > 1
+}
+
+// HasNext reports whether there is a page after the current one.
+func (p Paginator) HasNext() bool {
+	return p.CurrentPage < p.TotalPages
+}
+
+// PageURL returns base with its page query parameter set to page. Other
+// query parameters in base are preserved.
+func (p Paginator) PageURL(base string, page int) string {
+	u, err := url.Parse(base)
+	if err != nil {
+		return base
+	}
+
+	q := u.Query()
+	q.Set("page", strconv.Itoa(page))
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
+// PrevURL returns the URL of the previous page, or an empty string on the
+// first page.
+func (p Paginator) PrevURL(base string) string {
+	if !p.HasPrev() {
+		return ""
+	}
+	return p.PageURL(base, p.CurrentPage-1)
+}
+
+// NextURL returns the URL of the next page, or an empty string on the last
+// page.
+func (p Paginator) NextURL(base string) string {
+	if !p.HasNext() {
+		return ""
+	}
+	return p.PageURL(base, p.CurrentPage+1)
+}
+
+// PageRange returns up to window consecutive page numbers centred on the
+// current page and shifted to stay within 1..TotalPages. Templates render the
+// first and last pages and ellipses around it for "1 … 4 5 6 … 20" controls
+// by comparing its ends with 1 and TotalPages.
+func (p Paginator) PageRange(window int) []int {
+	if window < 1 {
+		return nil
+	}
+	if window >= p.TotalPages {
+		return p.Pages()
+	}
+
+	start := p.CurrentPage - (window-1)/2
+	start = min(max(start, 1), p.TotalPages-window+1)
+
+	pages := make([]int, 0, window)
+	for i := start; i < start+window; i++ {
+		pages = append(pages, i)
+	}
+	return pages
+}
@@ -0,0 +1,127 @@
+package gtml
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewPaginator(t *testing.T) {
+	tests := []struct {
+		name                string
+		current, per, total int
+		want                Paginator
+	}{
+		{name: "exact pages", current: 2, per: 10, total: 30, want: Paginator{CurrentPage: 2, TotalPages: 3, PerPage: 10, TotalItems: 30}},
+		{name: "partial last page", current: 1, per: 10, total: 31, want: Paginator{CurrentPage: 1, TotalPages: 4, PerPage: 10, TotalItems: 31}},
+		{name: "no items", current: 1, per: 10, total: 0, want: Paginator{CurrentPage: 1, TotalPages: 1, PerPage: 10, TotalItems: 0}},
+		{name: "current below range", current: 0, per: 10, total: 30, want: Paginator{CurrentPage: 1, TotalPages: 3, PerPage: 10, TotalItems: 30}},
+		{name: "current above range", current: 9, per: 10, total: 30, want: Paginator{CurrentPage: 3, TotalPages: 3, PerPage: 10, TotalItems: 30}},
+		{name: "invalid per page", current: 1, per: 0, total: 3, want: Paginator{CurrentPage: 1, TotalPages: 3, PerPage: 1, TotalItems: 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewPaginator(tt.current, tt.per, tt.total)
+			if *got != tt.want {
+				t.Errorf("NewPaginator(%d, %d, %d) = %+v, want %+v", tt.current, tt.per, tt.total, *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPaginatorPrevNext(t *testing.T) {
+	tests := []struct {
+		name    string
+		current int
+		hasPrev bool
+		hasNext bool
+		prevURL string
+		nextURL string
+	}{
+		{name: "first page", current: 1, hasPrev: false, hasNext: true, prevURL: "", nextURL: "/items?page=2&q=go"},
+		{name: "middle page", current: 3, hasPrev: true, hasNext: true, prevURL: "/items?page=2&q=go", nextURL: "/items?page=4&q=go"},
+		{name: "last page", current: 5, hasPrev: true, hasNext: false, prevURL: "/items?page=4&q=go", nextURL: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewPaginator(tt.current, 10, 50)
+			if got := p.HasPrev(); got != tt.hasPrev {
+				t.Errorf("HasPrev() = %v, want %v", got, tt.hasPrev)
+			}
+			if got := p.HasNext(); got != tt.hasNext {
+				t.Errorf("HasNext() = %v, want %v", got, tt.hasNext)
+			}
+			if got := p.PrevURL("/items?q=go&page=1"); got != tt.prevURL {
+				t.Errorf("PrevURL() = %q, want %q", got, tt.prevURL)
+			}
+			if got := p.NextURL("/items?q=go"); got != tt.nextURL {
+				t.Errorf("NextURL() = %q, want %q", got, tt.nextURL)
+			}
+		})
+	}
+}
+
+func TestPaginatorPages(t *testing.T) {
+	got := NewPaginator(1, 10, 35).Pages()
+	want := []int{1, 2, 3, 4}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Pages() = %v, want %v", got, want)
+	}
+}
+
+func TestPaginatorPageRange(t *testing.T) {
+	tests := []struct {
+		name       string
+		current    int
+		totalPages int
+		window     int
+		want       []int
+	}{
+		{name: "first page", current: 1, totalPages: 20, window: 5, want: []int{1, 2, 3, 4, 5}},
+		{name: "second page", current: 2, totalPages: 20, window: 5, want: []int{1, 2, 3, 4, 5}},
+		{name: "middle page", current: 10, totalPages: 20, window: 5, want: []int{8, 9, 10, 11, 12}},
+		{name: "middle page even window", current: 10, totalPages: 20, window: 4, want: []int{9, 10, 11, 12}},
+		{name: "next to last page", current: 19, totalPages: 20, window: 5, want: []int{16, 17, 18, 19, 20}},
+		{name: "last page", current: 20, totalPages: 20, window: 5, want: []int{16, 17, 18, 19, 20}},
+		{name: "fewer pages than window", current: 2, totalPages: 3, window: 5, want: []int{1, 2, 3}},
+		{name: "pages equal window", current: 5, totalPages: 5, window: 5, want: []int{1, 2, 3, 4, 5}},
+		{name: "single page", current: 1, totalPages: 1, window: 5, want: []int{1}},
+		{name: "window of one", current: 7, totalPages: 20, window: 1, want: []int{7}},
+		{name: "zero window", current: 7, totalPages: 20, window: 0, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewPaginator(tt.current, 1, tt.totalPages)
+			if got := p.PageRange(tt.window); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PageRange(%d) = %v, want %v", tt.window, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPaginateFunc(t *testing.T) {
+	text := `{{$p := paginate .Page 10 .Total}}` +
+		`{{range $p.PageRange 3}}{{if eq . $p.CurrentPage}}[{{.}}]{{else}}{{.}}{{end}} {{end}}` +
+		`{{if $p.HasNext}}<a href="{{$p.NextURL "/list"}}">next</a>{{end}}`
+
+	got := execute(t, nil, text, map[string]any{"Page": 4, "Total": 95})
+	want := `3 [4] 5 <a href="/list?page=5">next</a>`
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestPaginatorValueInViewData(t *testing.T) {
+	text := `{{with .P}}{{range .PageRange 3}}{{.}} {{end}}` +
+		`{{if .HasPrev}}<a href="{{.PrevURL "/list"}}">prev</a>{{end}}` +
+		`{{if .HasNext}}<a href="{{.NextURL "/list"}}">next</a>{{end}}{{end}}`
+
+	p := *NewPaginator(2, 10, 30)
+	got := execute(t, nil, text, map[string]any{"P": p})
+	want := `1 2 3 <a href="/list?page=1">prev</a><a href="/list?page=3">next</a>`
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}